@@ -0,0 +1,53 @@
+// Package errgrpc adapts tagged errors into classified gRPC status errors.
+package errgrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	errtags "github.com/HitoriSensei/error-tags"
+)
+
+// UnaryServerInterceptor resolves the tagged gRPC code for any error a
+// handler returns (falling back to codes.Unknown), encoding the error's
+// slog/JSON representation into the returned status message. This keeps the
+// detail in the plain message string rather than attached via
+// status.WithDetails: that's the more idiomatic home for structured detail,
+// but it requires a proto.Message payload, which this package intentionally
+// avoids depending on. Clients expecting a human-readable message will see
+// the raw JSON blob instead.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		code, ok := errtags.GRPCCode(err)
+		if !ok {
+			code = codes.Unknown
+		}
+
+		detail, marshalErr := json.Marshal(errorDetail(err))
+		if marshalErr != nil {
+			return resp, status.Error(code, err.Error())
+		}
+
+		return resp, status.Error(code, string(detail))
+	}
+}
+
+func errorDetail(err error) any {
+	//goland:noinspection GoTypeAssertionOnErrors
+	if tag, ok := err.(*errtags.Tag); ok {
+		return tag
+	}
+
+	return struct {
+		Message string `json:"message"`
+	}{Message: err.Error()}
+}
@@ -0,0 +1,54 @@
+package errgrpc
+
+import (
+	"context"
+	"testing"
+
+	errtags "github.com/HitoriSensei/error-tags"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptorResolvesTaggedCode(t *testing.T) {
+	notFound := errtags.NewTag("not found").WithGRPCCode(codes.NotFound)
+
+	interceptor := UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, notFound.Tag(errtags.NewTag("user does not exist"))
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, codes.NotFound, st.Code())
+	}
+}
+
+func TestUnaryServerInterceptorFallsBackToUnknown(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, errtags.NewTag("untagged failure")
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	st, ok := status.FromError(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, codes.Unknown, st.Code())
+	}
+}
+
+func TestUnaryServerInterceptorNoError(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
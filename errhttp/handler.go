@@ -0,0 +1,42 @@
+// Package errhttp adapts tagged errors into classified HTTP responses.
+package errhttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errtags "github.com/HitoriSensei/error-tags"
+)
+
+// Handler adapts next, a handler function that returns an error, into an
+// http.Handler. On success it does nothing further. On error it resolves
+// the tagged HTTP status (falling back to http.StatusInternalServerError)
+// and writes a JSON body derived from the error's slog/JSON representation.
+func Handler(next func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := next(w, r)
+		if err == nil {
+			return
+		}
+
+		status, ok := errtags.HTTPStatus(err)
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(errorBody(err))
+	})
+}
+
+func errorBody(err error) any {
+	//goland:noinspection GoTypeAssertionOnErrors
+	if tag, ok := err.(*errtags.Tag); ok {
+		return tag
+	}
+
+	return struct {
+		Message string `json:"message"`
+	}{Message: err.Error()}
+}
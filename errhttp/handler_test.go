@@ -0,0 +1,56 @@
+package errhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errtags "github.com/HitoriSensei/error-tags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerWritesTaggedStatus(t *testing.T) {
+	notFound := errtags.NewTag("not found").WithHTTPStatus(http.StatusNotFound)
+
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return notFound.Tag(errtags.NewTag("user does not exist"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "not found", body["message"])
+	assert.Equal(t, []any{"not found"}, body["tags"])
+}
+
+func TestHandlerFallsBackToInternalServerError(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errtags.NewTag("untagged failure")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestHandlerNoError(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
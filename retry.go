@@ -0,0 +1,116 @@
+package errtags
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Retryable marks an error as safe to retry/requeue. Attach it with Retry,
+// and use errors.Is(err, Retryable) (or RunWithRetry) to decide whether to
+// try again, instead of inventing a per-project sentinel for this.
+var Retryable = NewTag("retryable")
+
+// retryAfterFieldKey is the well-known Field key Retry/RetryAfter use to
+// carry the suggested delay.
+const retryAfterFieldKey = "retry_after"
+
+// Retry tags err as Retryable and attaches a suggested retry delay,
+// extractable later via RetryAfter.
+func Retry(err error, after time.Duration) error {
+	return Retryable.WithFields(err, retryAfterFieldKey, after)
+}
+
+// RetryAfter extracts the delay attached by Retry, preferring the innermost
+// (most specific) hint in err's causal chain.
+func RetryAfter(err error) (time.Duration, bool) {
+	after, ok := time.Duration(0), false
+
+	walkChain(err, func(tag *Tag) bool {
+		for _, field := range tag.fields {
+			if field.Key != retryAfterFieldKey {
+				continue
+			}
+			if d, valid := field.Value.(time.Duration); valid {
+				after, ok = d, true
+			}
+		}
+		return true
+	})
+
+	return after, ok
+}
+
+// retryConfig holds RunWithRetry's tunables; see the RetryOpt functions.
+type retryConfig struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// RetryOpt configures RunWithRetry.
+type RetryOpt func(*retryConfig)
+
+// WithMaxAttempts caps the number of attempts RunWithRetry makes, including
+// the first. The default is unlimited, bounded only by ctx.
+func WithMaxAttempts(n int) RetryOpt {
+	return func(c *retryConfig) { c.maxAttempts = n }
+}
+
+// WithBaseDelay sets the starting delay for the capped exponential backoff
+// used between attempts when an error carries no RetryAfter hint.
+func WithBaseDelay(d time.Duration) RetryOpt {
+	return func(c *retryConfig) { c.baseDelay = d }
+}
+
+// WithMaxDelay caps the exponential backoff used between attempts when an
+// error carries no RetryAfter hint.
+func WithMaxDelay(d time.Duration) RetryOpt {
+	return func(c *retryConfig) { c.maxDelay = d }
+}
+
+// RunWithRetry calls fn, retrying only while the returned error satisfies
+// errors.Is(err, Retryable). It waits the innermost RetryAfter hint between
+// attempts when one is present, and otherwise backs off exponentially
+// (capped by WithMaxDelay). It stops immediately on a non-retryable error,
+// on reaching WithMaxAttempts, or when ctx is done.
+func RunWithRetry(ctx context.Context, fn func() error, opts ...RetryOpt) error {
+	cfg := retryConfig{
+		baseDelay: 100 * time.Millisecond,
+		maxDelay:  30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	delay := cfg.baseDelay
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, Retryable) {
+			return err
+		}
+		if cfg.maxAttempts > 0 && attempt >= cfg.maxAttempts {
+			return err
+		}
+
+		wait := delay
+		if hint, ok := RetryAfter(err); ok {
+			wait = hint
+		} else {
+			delay *= 2
+			if delay > cfg.maxDelay {
+				delay = cfg.maxDelay
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
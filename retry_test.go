@@ -0,0 +1,114 @@
+package errtags
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	someError := stderrors.New("some error")
+
+	tagged := Retry(someError, 50*time.Millisecond)
+
+	assert.ErrorIs(t, tagged, Retryable)
+
+	after, ok := RetryAfter(tagged)
+	assert.True(t, ok)
+	assert.Equal(t, 50*time.Millisecond, after)
+
+	_, ok = RetryAfter(someError)
+	assert.False(t, ok)
+}
+
+func TestRetryAfterPrefersInnermost(t *testing.T) {
+	someError := stderrors.New("some error")
+
+	inner := Retry(someError, 10*time.Millisecond)
+	outer := Retry(inner, 50*time.Millisecond)
+
+	after, ok := RetryAfter(outer)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Millisecond, after)
+}
+
+func TestRunWithRetrySucceedsAfterRetries(t *testing.T) {
+	someError := stderrors.New("flaky")
+
+	attempts := 0
+	err := RunWithRetry(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return Retry(someError, time.Millisecond)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRunWithRetryStopsOnNonRetryable(t *testing.T) {
+	someError := stderrors.New("permanent")
+
+	attempts := 0
+	err := RunWithRetry(context.Background(), func() error {
+		attempts++
+		return someError
+	})
+
+	assert.Equal(t, someError, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRunWithRetryStopsOnMaxAttempts(t *testing.T) {
+	someError := stderrors.New("always flaky")
+
+	attempts := 0
+	err := RunWithRetry(context.Background(), func() error {
+		attempts++
+		return Retry(someError, time.Millisecond)
+	}, WithMaxAttempts(2))
+
+	assert.ErrorIs(t, err, Retryable)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRunWithRetryBacksOffExponentiallyWithoutHint(t *testing.T) {
+	someError := stderrors.New("always flaky")
+
+	attempts := 0
+	start := time.Now()
+	err := RunWithRetry(context.Background(), func() error {
+		attempts++
+		// no RetryAfter hint, so RunWithRetry must fall back to its own
+		// capped exponential backoff: 10ms, 20ms, then capped at 25ms.
+		return WithTags(someError, Retryable)
+	}, WithMaxAttempts(4), WithBaseDelay(10*time.Millisecond), WithMaxDelay(25*time.Millisecond))
+	elapsed := time.Since(start)
+
+	assert.ErrorIs(t, err, Retryable)
+	assert.Equal(t, 4, attempts)
+	// three waits of 10ms + 20ms + 25ms (capped) between the four attempts
+	assert.GreaterOrEqual(t, elapsed, 55*time.Millisecond)
+	assert.Less(t, elapsed, 200*time.Millisecond)
+}
+
+func TestRunWithRetryStopsOnContextDone(t *testing.T) {
+	someError := stderrors.New("always flaky")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := RunWithRetry(ctx, func() error {
+		attempts++
+		return Retry(someError, time.Second)
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
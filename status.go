@@ -0,0 +1,77 @@
+package errtags
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// httpStatusByTag and grpcCodeByTag let a classification tag declare the
+// HTTP/gRPC response it represents, at the point where the tag is defined
+// (e.g. var NotFound = NewTag("not found").WithHTTPStatus(http.StatusNotFound)).
+// statusMu guards both maps, since WithHTTPStatus/WithGRPCCode can otherwise
+// race with HTTPStatus/GRPCCode if a tag is registered after server startup
+// instead of at init time.
+var (
+	statusMu        sync.RWMutex
+	httpStatusByTag = map[*Tag]int{}
+	grpcCodeByTag   = map[*Tag]codes.Code{}
+)
+
+// WithHTTPStatus records the HTTP status tag represents, and returns e so
+// calls can be chained onto NewTag.
+func (e *Tag) WithHTTPStatus(status int) *Tag {
+	statusMu.Lock()
+	httpStatusByTag[e] = status
+	statusMu.Unlock()
+	return e
+}
+
+// WithGRPCCode records the gRPC code tag represents, and returns e so calls
+// can be chained onto NewTag.
+func (e *Tag) WithGRPCCode(code codes.Code) *Tag {
+	statusMu.Lock()
+	grpcCodeByTag[e] = code
+	statusMu.Unlock()
+	return e
+}
+
+// HTTPStatus walks err's causal chain for a tag carrying an HTTP status,
+// returning the most specific (innermost) one found.
+func HTTPStatus(err error) (int, bool) {
+	status, ok := 0, false
+
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+
+	walkChain(err, func(tag *Tag) bool {
+		for _, t := range getAllTags(tag.tags, nil) {
+			if s, found := httpStatusByTag[t]; found {
+				status, ok = s, true
+			}
+		}
+		return true
+	})
+
+	return status, ok
+}
+
+// GRPCCode walks err's causal chain for a tag carrying a gRPC code,
+// returning the most specific (innermost) one found.
+func GRPCCode(err error) (codes.Code, bool) {
+	code, ok := codes.Code(0), false
+
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+
+	walkChain(err, func(tag *Tag) bool {
+		for _, t := range getAllTags(tag.tags, nil) {
+			if c, found := grpcCodeByTag[t]; found {
+				code, ok = c, true
+			}
+		}
+		return true
+	})
+
+	return code, ok
+}
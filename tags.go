@@ -1,9 +1,12 @@
 package errtags
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"reflect"
+	"runtime"
 	"slices"
 	"strings"
 )
@@ -13,6 +16,9 @@ type Tag struct {
 	msg         string
 	tags        []*Tag
 	msgOverride bool
+	pc          []uintptr
+	fields      []Field
+	name        string
 }
 
 // Tag adds tags to the error.
@@ -28,6 +34,23 @@ func (e *Tag) Tag(err error) error {
 		causer: err,
 		msg:    e.msg,
 		tags:   e.tags,
+		pc:     callers(3),
+	}
+}
+
+// WithFields tags err the same way Tag does, additionally attaching the
+// given key/value fields (see the package-level WithFields for the kv format).
+func (e *Tag) WithFields(err error, kv ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Tag{
+		causer: err,
+		msg:    e.msg,
+		tags:   e.tags,
+		pc:     callers(3),
+		fields: fieldsFromKV(kv),
 	}
 }
 
@@ -64,6 +87,10 @@ func (e *Tag) Error() string {
 		return ownMessage
 	}
 
+	if ownMessage == "" {
+		return e.causer.Error()
+	}
+
 	return ownMessage + ": " + e.causer.Error()
 }
 
@@ -78,7 +105,19 @@ func (e *Tag) Format(s fmt.State, verb rune) {
 
 	if fmtr, ok := e.causer.(fmt.Formatter); ok {
 		fmtr.Format(s, verb)
-		return
+	} else if e.causer != nil {
+		_, _ = io.WriteString(s, e.causer.Error())
+	}
+
+	if verb == 'v' && s.Flag('+') {
+		// Only the innermost stack in the chain is printed: if the causer
+		// already carries its own stack (another *Tag, or a third-party
+		// error such as github.com/pkg/errors), it was already written
+		// above and printing ours too would just duplicate it.
+		if !hasStackTrace(e.causer) {
+			e.printStack(s)
+		}
+		e.printFields(s)
 	}
 }
 
@@ -105,6 +144,7 @@ func (e *Tag) TagWithMessage(err error, msg string) error {
 		msg:         msg,
 		msgOverride: true,
 		tags:        e.tags,
+		pc:          callers(3),
 	}
 
 	return &tag
@@ -112,11 +152,12 @@ func (e *Tag) TagWithMessage(err error, msg string) error {
 
 // NewTag creates a new Tag with optional message.
 func NewTag(msg ...string) *Tag {
-	tag := Tag{}
+	tag := Tag{pc: callers(3)}
 	if len(msg) > 0 {
 		tag.msg = strings.Join(msg, ": ")
 	}
 	tag.tags = []*Tag{&tag}
+	tag.name = tagIdentity(tag.msg, tag.pc)
 	return &tag
 }
 
@@ -133,6 +174,7 @@ func WithTags(err error, tags ...*Tag) error {
 	return &Tag{
 		causer: err,
 		tags:   sort(tags),
+		pc:     callers(3),
 	}
 }
 
@@ -145,10 +187,27 @@ func WithTagsAndMessage(err error, msg string, tags ...*Tag) error {
 	tagged := WithTags(err, tags...).(*Tag)
 	tagged.msg = msg
 	tagged.msgOverride = true
+	tagged.pc = callers(3)
 
 	return tagged
 }
 
+// WithFields attaches structured key/value fields to err, independently of
+// any tag classification. kv is an alternating list of string keys and
+// arbitrary values (e.g. WithFields(err, "user_id", 42, "request_id", "abc"));
+// a key without a paired value is dropped.
+func WithFields(err error, kv ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Tag{
+		causer: err,
+		pc:     callers(3),
+		fields: fieldsFromKV(kv),
+	}
+}
+
 func UnionTag(tag *Tag, tags ...*Tag) *Tag {
 	return &Tag{
 		tags: sort(append(tags, tag)),
@@ -215,3 +274,390 @@ func (e *Tag) Unwrap() error {
 func Equal(a, b *Tag) bool {
 	return a.Is(b) && b.Is(a)
 }
+
+// stack traces
+
+// Frame describes a single call stack entry, resolved into human-readable form.
+type Frame struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// maxStackDepth bounds how many call frames are captured; following the
+// pkg/errors convention, this comfortably covers real-world call chains.
+const maxStackDepth = 32
+
+// callers captures the program counters of the goroutine's current call
+// stack, skipping the given number of innermost frames (runtime.Callers
+// itself, this function, and any wrapper frames the caller wants hidden).
+func callers(skip int) []uintptr {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip, pcs[:])
+	return pcs[:n]
+}
+
+// StackTrace returns the call stack captured when the tag was applied to an
+// error, resolved into Frames via runtime.CallersFrames.
+func (e *Tag) StackTrace() []Frame {
+	if len(e.pc) == 0 {
+		return nil
+	}
+
+	rframes := runtime.CallersFrames(e.pc)
+	frames := make([]Frame, 0, len(e.pc))
+	for {
+		rframe, more := rframes.Next()
+		frames = append(frames, Frame{
+			File:     rframe.File,
+			Line:     rframe.Line,
+			Function: rframe.Function,
+		})
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
+
+// printStack writes the captured stack trace in pkg/errors' familiar
+// "\nfunction\n\tfile:line" shape.
+func (e *Tag) printStack(s fmt.State) {
+	for _, frame := range e.StackTrace() {
+		_, _ = fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+}
+
+// hasStackTrace reports whether err, or anything in its causal chain,
+// already exposes a non-empty stack trace. It recognizes *Tag directly, as
+// well as any error exposing a no-argument StackTrace() method (e.g. the
+// stack traces produced by github.com/pkg/errors), without taking a
+// dependency on that package. A method that exists but returns no frames
+// (e.g. a *Tag built without capturing pc, like UnionTag's result) doesn't
+// count, so the caller still gets a real stack printed.
+func hasStackTrace(err error) bool {
+	for err != nil {
+		method := reflect.ValueOf(err).MethodByName("StackTrace")
+		if method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() == 1 {
+			if result := method.Call(nil)[0]; result.Kind() == reflect.Slice && result.Len() > 0 {
+				return true
+			}
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Cause() error }:
+			err = x.Cause()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// structured fields
+
+// Field is a single structured key/value annotation attached to a tagged error.
+type Field struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// fieldsFromKV converts an alternating key/value argument list into Fields.
+// A trailing key without a value, or a key that isn't a string, is dropped.
+func fieldsFromKV(kv []any) []Field {
+	fields := make([]Field, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+		fields = append(fields, Field{Key: key, Value: kv[i+1]})
+	}
+	return fields
+}
+
+// Fields walks err's causal chain (including joined branches) and collects
+// every Field attached along the way, outer-first, keeping only the
+// outermost occurrence of each key.
+func Fields(err error) []Field {
+	var fields []Field
+	seen := make(map[string]struct{})
+
+	walkChain(err, func(tag *Tag) bool {
+		for _, field := range tag.fields {
+			if _, dup := seen[field.Key]; dup {
+				continue
+			}
+			seen[field.Key] = struct{}{}
+			fields = append(fields, field)
+		}
+		return true
+	})
+
+	return fields
+}
+
+// printFields writes this tag's own fields as a "key=value" list.
+func (e *Tag) printFields(s fmt.State) {
+	for _, field := range e.fields {
+		_, _ = fmt.Fprintf(s, "\n%s=%v", field.Key, field.Value)
+	}
+}
+
+// slog and JSON
+
+// TagName returns tag's identity string, as recorded when it was created
+// with NewTag: its message, or a caller file:line when it has none. This
+// keeps tag lists in logs stable and human-readable even when multiple tags
+// share the same message.
+func TagName(tag *Tag) string {
+	return tag.name
+}
+
+// tagIdentity derives a tag's identity: its message when it has one,
+// otherwise the file:line of the frame that created it.
+func tagIdentity(msg string, pc []uintptr) string {
+	if msg != "" {
+		return msg
+	}
+	if len(pc) == 0 {
+		return ""
+	}
+
+	frame, _ := runtime.CallersFrames(pc[:1]).Next()
+	return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+}
+
+// tagNames collects the identity of each tag, in order.
+func tagNames(tags []*Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.name)
+	}
+	return names
+}
+
+// LogValue implements slog.LogValuer so that passing a tagged error to slog
+// (e.g. slog.Any("err", err)) expands it into a structured group instead of
+// just its Error() string.
+func (e *Tag) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4)
+	attrs = append(attrs, slog.String("message", e.message()))
+
+	if names := tagNames(getAllTags(e.tags, nil)); len(names) > 0 {
+		attrs = append(attrs, slog.Any("tags", names))
+	}
+
+	if len(e.fields) > 0 {
+		fieldArgs := make([]any, 0, len(e.fields))
+		for _, field := range e.fields {
+			fieldArgs = append(fieldArgs, slog.Any(field.Key, field.Value))
+		}
+		attrs = append(attrs, slog.Group("fields", fieldArgs...))
+	}
+
+	if e.causer != nil {
+		attrs = append(attrs, slog.Any("cause", e.causer))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// tagJSON mirrors the shape produced by LogValue, for serializing tagged
+// errors in HTTP JSON responses and log pipelines.
+type tagJSON struct {
+	Message string         `json:"message"`
+	Tags    []string       `json:"tags,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+	Cause   any            `json:"cause,omitempty"`
+}
+
+// MarshalJSON serializes the tag into the same shape exposed by LogValue.
+func (e *Tag) MarshalJSON() ([]byte, error) {
+	out := tagJSON{
+		Message: e.message(),
+		Tags:    tagNames(getAllTags(e.tags, nil)),
+	}
+
+	if len(e.fields) > 0 {
+		out.Fields = make(map[string]any, len(e.fields))
+		for _, field := range e.fields {
+			out.Fields[field.Key] = field.Value
+		}
+	}
+
+	if e.causer != nil {
+		if _, ok := e.causer.(json.Marshaler); ok {
+			out.Cause = e.causer
+		} else {
+			out.Cause = e.causer.Error()
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// chain traversal
+
+// walkChain visits every *Tag node in err's causal chain, following both
+// Unwrap() error and the errors.Join-style Unwrap() []error, in depth-first
+// outer-first order. Traversal stops as soon as visit returns false.
+func walkChain(err error, visit func(tag *Tag) bool) bool {
+	if err == nil {
+		return true
+	}
+
+	//goland:noinspection GoTypeAssertionOnErrors
+	if tag, ok := err.(*Tag); ok {
+		if !visit(tag) {
+			return false
+		}
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkChain(x.Unwrap(), visit)
+	case interface{ Unwrap() []error }:
+		for _, child := range x.Unwrap() {
+			if !walkChain(child, visit) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// containsTag reports whether target is one of tags.
+func containsTag(tags []*Tag, target *Tag) bool {
+	for _, tag := range tags {
+		if tag == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Find walks err's causal chain and returns the *Tag node that carries
+// target, or nil if none does. Unlike a plain errors.Is check, this returns
+// the concrete node so callers can read its message, fields, or stack trace.
+func Find(err error, target *Tag) *Tag {
+	var found *Tag
+
+	walkChain(err, func(tag *Tag) bool {
+		if containsTag(getAllTags(tag.tags, nil), target) {
+			found = tag
+			return false
+		}
+		return true
+	})
+
+	return found
+}
+
+// FindAll returns every *Tag node present in err's causal chain, outer-first.
+func FindAll(err error) []*Tag {
+	var found []*Tag
+
+	walkChain(err, func(tag *Tag) bool {
+		found = append(found, tag)
+		return true
+	})
+
+	return found
+}
+
+// HasAnyTag reports whether err's causal chain carries at least one of tags.
+func HasAnyTag(err error, tags ...*Tag) bool {
+	for _, tag := range tags {
+		if Find(err, tag) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllTags reports whether err's causal chain carries every one of tags.
+func HasAllTags(err error, tags ...*Tag) bool {
+	for _, tag := range tags {
+		if Find(err, tag) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// multi-error support
+
+// joinTag is the error returned by JoinTagged. *Tag can't grow an
+// Unwrap() []error method of its own (it already has a single-causer
+// Unwrap() error), so joining lives in this companion type instead; its
+// Unwrap() []error is enough for errors.Is, Find, and FindAll to visit every
+// branch, since each branch keeps its own tags intact.
+type joinTag struct {
+	errs []error
+	tags []*Tag
+}
+
+// JoinTagged combines errs into a single error, the way errors.Join does,
+// but preserves each constituent's tags so Tags(), errors.Is, Find, and
+// FindAll still see them. nil errors are dropped, matching errors.Join.
+func JoinTagged(errs ...error) error {
+	var tags []*Tag
+	var nonNil []error
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		nonNil = append(nonNil, err)
+		if tagger, ok := err.(interface{ Tags() []*Tag }); ok {
+			tags = append(tags, tagger.Tags()...)
+		}
+	}
+
+	if len(nonNil) == 0 {
+		return nil
+	}
+
+	return &joinTag{errs: nonNil, tags: sort(tags)}
+}
+
+func (j *joinTag) Tags() []*Tag {
+	return j.tags
+}
+
+func (j *joinTag) Unwrap() []error {
+	return j.errs
+}
+
+// Error renders a single-line "; "-joined form of the constituent errors.
+func (j *joinTag) Error() string {
+	parts := make([]string, len(j.errs))
+	for i, err := range j.errs {
+		parts[i] = err.Error()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Format renders a bulleted multi-line list under %+v, and the same
+// single-line form as Error() for every other verb.
+func (j *joinTag) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		for _, err := range j.errs {
+			_, _ = io.WriteString(s, "\n- ")
+			if fmtr, ok := err.(fmt.Formatter); ok {
+				fmtr.Format(s, verb)
+			} else {
+				_, _ = io.WriteString(s, err.Error())
+			}
+		}
+		return
+	}
+
+	_, _ = io.WriteString(s, j.Error())
+}
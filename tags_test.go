@@ -1,12 +1,16 @@
 package errtags
 
 import (
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 func TestColors(t *testing.T) {
@@ -77,6 +81,39 @@ func TestStack(t *testing.T) {
 	assert.Equal(t, baseErrorPrint, wrappedErrorPrint)
 }
 
+func TestStackOnStacklessCauser(t *testing.T) {
+	var someClass = NewTag("class message")
+
+	baseError := stderrors.New("some message")
+	wrappedError := someClass.Tag(baseError)
+
+	printed := fmt.Sprintf("%+v", wrappedError)
+
+	assert.True(t, strings.HasPrefix(printed, "class message: some message"))
+	assert.Contains(t, printed, "TestStackOnStacklessCauser")
+
+	tag := wrappedError.(*Tag)
+	frames := tag.StackTrace()
+	assert.NotEmpty(t, frames)
+	assert.Contains(t, frames[0].Function, "TestStackOnStacklessCauser")
+	assert.True(t, strings.HasSuffix(frames[0].File, "tags_test.go"))
+}
+
+func TestStackOnUnionTagCauser(t *testing.T) {
+	var a = NewTag("a")
+	var b = NewTag("b")
+	var outer = NewTag("outer")
+
+	// UnionTag builds a bare *Tag with no captured pc, so its own
+	// StackTrace() is empty; outer must not mistake that for "already has a
+	// stack" and must print its own frames instead.
+	wrappedError := outer.Tag(UnionTag(a, b))
+
+	printed := fmt.Sprintf("%+v", wrappedError)
+
+	assert.Contains(t, printed, "TestStackOnUnionTagCauser")
+}
+
 func TestMessage(t *testing.T) {
 	var someClass = NewTag("class message")
 
@@ -199,6 +236,236 @@ func TestTags(t *testing.T) {
 	assert.Equal(t, "red tag: blue tag: some error", withTags.Error())
 }
 
+func TestWithFields(t *testing.T) {
+	someError := errors.New("some error")
+
+	tagged := WithFields(someError, "user_id", 42, "request_id", "abc")
+
+	assert.Equal(t, []Field{{Key: "user_id", Value: 42}, {Key: "request_id", Value: "abc"}}, Fields(tagged))
+	// fields must not leak into Error() so errors.Is semantics don't shift
+	assert.Equal(t, "some error", tagged.Error())
+}
+
+func TestWithFieldsDanglingKey(t *testing.T) {
+	someError := errors.New("some error")
+
+	tagged := WithFields(someError, "user_id", 42, "dangling")
+
+	assert.Equal(t, []Field{{Key: "user_id", Value: 42}}, Fields(tagged))
+}
+
+func TestTagWithFields(t *testing.T) {
+	redTag := NewTag("red tag")
+
+	someError := errors.New("some error")
+
+	tagged := redTag.WithFields(someError, "user_id", 42)
+
+	assert.ErrorIs(t, tagged, redTag)
+	assert.Equal(t, []Field{{Key: "user_id", Value: 42}}, Fields(tagged))
+	assert.Equal(t, "red tag: some error", tagged.Error())
+}
+
+func TestFormatIncludesFields(t *testing.T) {
+	redTag := NewTag("red tag")
+
+	baseError := errors.New("base error")
+	tagged := redTag.WithFields(baseError, "user_id", 42, "request_id", "abc")
+
+	printed := fmt.Sprintf("%+v", tagged)
+
+	assert.True(t, strings.HasPrefix(printed, "red tag: base error"))
+	assert.Contains(t, printed, "\nuser_id=42")
+	assert.Contains(t, printed, "\nrequest_id=abc")
+
+	// fields must not leak into %v or Error()
+	assert.Equal(t, "red tag: base error", fmt.Sprintf("%v", tagged))
+}
+
+func TestFieldsOuterFirstDeduplicated(t *testing.T) {
+	someError := errors.New("some error")
+
+	inner := WithFields(someError, "scope", "inner", "request_id", "abc")
+	outer := WithFields(inner, "scope", "outer")
+
+	assert.Equal(t, []Field{{Key: "scope", Value: "outer"}, {Key: "request_id", Value: "abc"}}, Fields(outer))
+}
+
+func TestFieldsThroughJoinTagged(t *testing.T) {
+	someError := errors.New("some error")
+	tagged := WithFields(someError, "request_id", "abc")
+
+	joined := JoinTagged(tagged)
+
+	assert.Equal(t, []Field{{Key: "request_id", Value: "abc"}}, Fields(joined))
+}
+
+func TestTagName(t *testing.T) {
+	redTag := NewTag("red tag")
+	unnamed := NewTag()
+
+	assert.Equal(t, "red tag", TagName(redTag))
+	assert.Contains(t, TagName(unnamed), "tags_test.go:")
+}
+
+func TestLogValue(t *testing.T) {
+	redTag := NewTag("red tag")
+
+	someError := stderrors.New("some error")
+	tagged := redTag.WithFields(someError, "user_id", 42)
+
+	var buf strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("failed", slog.Any("err", tagged))
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(buf.String()), &record))
+
+	errGroup, ok := record["err"].(map[string]any)
+	if assert.True(t, ok) {
+		assert.Equal(t, "red tag", errGroup["message"])
+		assert.Equal(t, []any{"red tag"}, errGroup["tags"])
+		assert.Equal(t, map[string]any{"user_id": float64(42)}, errGroup["fields"])
+		assert.Equal(t, "some error", errGroup["cause"])
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	redTag := NewTag("red tag")
+
+	someError := stderrors.New("some error")
+	tagged := redTag.WithFields(someError, "user_id", 42)
+
+	data, err := json.Marshal(tagged)
+	assert.NoError(t, err)
+
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, "red tag", decoded["message"])
+	assert.Equal(t, []any{"red tag"}, decoded["tags"])
+	assert.Equal(t, map[string]any{"user_id": float64(42)}, decoded["fields"])
+	assert.Equal(t, "some error", decoded["cause"])
+}
+
+func TestFind(t *testing.T) {
+	redTag := NewTag("red tag")
+	blueTag := NewTag("blue tag")
+
+	someError := errors.New("some error")
+	tagged := redTag.Tag(someError)
+
+	found := Find(tagged, redTag)
+	if assert.NotNil(t, found) {
+		assert.Same(t, tagged, found)
+	}
+
+	assert.Nil(t, Find(tagged, blueTag))
+}
+
+func TestFindAll(t *testing.T) {
+	redTag := NewTag("red tag")
+	blueTag := NewTag("blue tag")
+
+	someError := errors.New("some error")
+	tagged := blueTag.Tag(redTag.Tag(someError))
+
+	found := FindAll(tagged)
+	assert.Equal(t, []*Tag{tagged.(*Tag), tagged.(*Tag).Unwrap().(*Tag)}, found)
+}
+
+func TestHasAnyAllTags(t *testing.T) {
+	redTag := NewTag("red tag")
+	blueTag := NewTag("blue tag")
+	greenTag := NewTag("green tag")
+
+	someError := errors.New("some error")
+	tagged := WithTags(someError, redTag, blueTag)
+
+	assert.True(t, HasAnyTag(tagged, greenTag, redTag))
+	assert.False(t, HasAnyTag(tagged, greenTag))
+
+	assert.True(t, HasAllTags(tagged, redTag, blueTag))
+	assert.False(t, HasAllTags(tagged, redTag, greenTag))
+}
+
+func TestJoinTagged(t *testing.T) {
+	redTag := NewTag("red tag")
+	blueTag := NewTag("blue tag")
+
+	redError := redTag.Tag(errors.New("red error"))
+	blueError := blueTag.Tag(errors.New("blue error"))
+
+	joined := JoinTagged(redError, blueError)
+
+	assert.ErrorIs(t, joined, redTag)
+	assert.ErrorIs(t, joined, blueTag)
+	assert.Equal(t, []*Tag{redTag, blueTag}, joined.(interface{ Tags() []*Tag }).Tags())
+
+	assert.Equal(t, "red tag: red error; blue tag: blue error", fmt.Sprintf("%v", joined))
+	assert.Equal(t, joined.Error(), fmt.Sprintf("%v", joined))
+
+	full := fmt.Sprintf("%+v", joined)
+	assert.True(t, strings.HasPrefix(full, "\n- red tag: red error"))
+	assert.Contains(t, full, "\n- blue tag: blue error")
+}
+
+func TestJoinTaggedDropsNil(t *testing.T) {
+	redTag := NewTag("red tag")
+	redError := redTag.Tag(errors.New("red error"))
+
+	joined := JoinTagged(nil, redError, nil)
+
+	assert.ErrorIs(t, joined, redTag)
+	assert.Equal(t, "red tag: red error", joined.Error())
+
+	assert.Nil(t, JoinTagged(nil, nil))
+}
+
+func TestJoinTaggedFindAll(t *testing.T) {
+	redTag := NewTag("red tag")
+	blueTag := NewTag("blue tag")
+
+	redError := redTag.Tag(errors.New("red error"))
+	blueError := blueTag.Tag(errors.New("blue error"))
+
+	joined := JoinTagged(redError, blueError)
+
+	assert.ElementsMatch(t, []*Tag{redError.(*Tag), blueError.(*Tag)}, FindAll(joined))
+	assert.True(t, HasAllTags(joined, redTag, blueTag))
+}
+
+func TestHTTPStatusAndGRPCCode(t *testing.T) {
+	notFound := NewTag("not found").WithHTTPStatus(404).WithGRPCCode(codes.NotFound)
+	internal := NewTag("internal error")
+
+	someError := errors.New("some error")
+	tagged := notFound.Tag(someError)
+
+	status, ok := HTTPStatus(tagged)
+	assert.True(t, ok)
+	assert.Equal(t, 404, status)
+
+	code, ok := GRPCCode(tagged)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, code)
+
+	_, ok = HTTPStatus(internal.Tag(someError))
+	assert.False(t, ok)
+}
+
+func TestHTTPStatusPrefersInnermost(t *testing.T) {
+	outer := NewTag("outer").WithHTTPStatus(500)
+	inner := NewTag("inner").WithHTTPStatus(409)
+
+	someError := errors.New("some error")
+	tagged := outer.Tag(inner.Tag(someError))
+
+	status, ok := HTTPStatus(tagged)
+	assert.True(t, ok)
+	assert.Equal(t, 409, status)
+}
+
 func TestWithTagsAndMessage(t *testing.T) {
 	redTag := NewTag("red tag")
 	blueTag := NewTag("blue tag")
@@ -258,7 +525,8 @@ func TestUnion(t *testing.T) {
 	unionBTagged := unionB.Tag(someError)
 
 	assert.Equal(t, unionA, unionB)
-	assert.Equal(t, unionATagged, unionBTagged)
+	// captured at different call sites, so their stack traces differ; compare tags instead of struct equality
+	assert.True(t, Equal(unionATagged.(*Tag), unionBTagged.(*Tag)))
 
 	assert.ErrorIs(t, unionATagged, unionA)
 	assert.ErrorIs(t, unionATagged, unionB)
@@ -275,10 +543,11 @@ func TestUnion(t *testing.T) {
 	// WithTags works the same as tagging with UnionTag
 	withTagsTaggedA := WithTags(someError, redTag, blueTag) // same as UnionTag(redTag, blueTag).Tag(someError)
 	withTagsTaggedB := WithTags(someError, blueTag, redTag) // same as UnionTag(blueTag, redTag).Tag(someError)
-	assert.Equal(t, unionATagged, withTagsTaggedA)
-	assert.Equal(t, unionBTagged, withTagsTaggedA)
-	assert.Equal(t, unionATagged, withTagsTaggedB)
-	assert.Equal(t, unionBTagged, withTagsTaggedB)
+	// captured at different call sites, so their stack traces differ; compare tags instead of struct equality
+	assert.True(t, Equal(unionATagged.(*Tag), withTagsTaggedA.(*Tag)))
+	assert.True(t, Equal(unionBTagged.(*Tag), withTagsTaggedA.(*Tag)))
+	assert.True(t, Equal(unionATagged.(*Tag), withTagsTaggedB.(*Tag)))
+	assert.True(t, Equal(unionBTagged.(*Tag), withTagsTaggedB.(*Tag)))
 
 	assert.ErrorIs(t, withTagsTaggedA, unionA)
 	assert.ErrorIs(t, withTagsTaggedA, unionB)